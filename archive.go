@@ -0,0 +1,133 @@
+package entropy
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// isArchive reports whether filename looks like an archive we know how to open.
+func isArchive(filename string) bool {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"), strings.HasSuffix(filename, ".tar.bz2"):
+		return true
+	}
+
+	switch path.Ext(filename) {
+	case ".zip", ".tar", ".tgz", ".tbz2":
+		return true
+	}
+
+	return false
+}
+
+// readArchive reads name out of fsys as an archive and feeds every regular file it
+// contains through scanContent, tagging results with a synthetic "archive!inner/path"
+// name. Errors opening individual members are reported on stderr but don't abort
+// the rest of the scan. Tar-based formats are read as a single sequential stream;
+// only zip needs random access, since its central directory sits at the end of the
+// file.
+func readArchive(entropies *Entropies, opts Options, fsys fs.FS, name string) error {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return scanZipArchive(entropies, opts, name, file)
+	case strings.HasSuffix(name, ".tar"):
+		return scanTarArchive(entropies, opts, name, file)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gzReader, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gzReader.Close()
+		return scanTarArchive(entropies, opts, name, gzReader)
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"):
+		return scanTarArchive(entropies, opts, name, bzip2.NewReader(file))
+	}
+
+	return fmt.Errorf("unsupported archive type: %s", name)
+}
+
+// scanZipArchive opens name's zip.Reader directly over file when it implements
+// io.ReaderAt (the common case for os.DirFS and similar real filesystems), and
+// only falls back to reading the whole archive into memory when it doesn't.
+func scanZipArchive(entropies *Entropies, opts Options, name string, file fs.File) error {
+	var readerAt io.ReaderAt
+	var size int64
+
+	if ra, ok := file.(io.ReaderAt); ok {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		readerAt, size = ra, info.Size()
+	} else {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return err
+		}
+		readerAt, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	zipReader, err := zip.NewReader(readerAt, size)
+	if err != nil {
+		return err
+	}
+
+	for _, zipFile := range zipReader.File {
+		if zipFile.FileInfo().IsDir() {
+			continue
+		}
+
+		innerName := fmt.Sprintf("%s!%s", name, zipFile.Name)
+		reader, err := zipFile.Open()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading archive member %s: %v\n", innerName, err)
+			continue
+		}
+
+		if err := scanContent(entropies, opts, innerName, reader); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading archive member %s: %v\n", innerName, err)
+		}
+		reader.Close()
+	}
+
+	return nil
+}
+
+func scanTarArchive(entropies *Entropies, opts Options, name string, r io.Reader) error {
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		innerName := fmt.Sprintf("%s!%s", name, header.Name)
+		if err := scanContent(entropies, opts, innerName, tarReader); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading archive member %s: %v\n", innerName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package entropy
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestScanZipArchive(t *testing.T) {
+	t.Run("well-formed archive, scanned by real path (io.ReaderAt)", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("inner/secret.txt")
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := w.Write([]byte("token aX7qP2mZ9kLrT3vB8cQ1wN\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "data.zip"), buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		opts := DefaultOptions()
+		opts.ScanArchives = true
+
+		res := ScanRoot(os.DirFS(dir), opts, "data.zip")
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+		Expect(t, res.Entropies[0].File, "data.zip!inner/secret.txt")
+	})
+
+	t.Run("malformed archive does not abort the scan", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"bad.zip":   &fstest.MapFile{Data: []byte("not a real zip")},
+			"clean.txt": &fstest.MapFile{Data: []byte("token aX7qP2mZ9kLrT3vB8cQ1wN\n")},
+		}
+
+		opts := DefaultOptions()
+		opts.ScanArchives = true
+
+		res := Scan(fsys, opts)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+	})
+}
+
+func TestScanTarArchive(t *testing.T) {
+	t.Run("tar", func(t *testing.T) {
+		fsys := fstest.MapFS{"data.tar": &fstest.MapFile{Data: buildTar(t, "inner/secret.txt", "token aX7qP2mZ9kLrT3vB8cQ1wN\n")}}
+
+		opts := DefaultOptions()
+		opts.ScanArchives = true
+
+		res := Scan(fsys, opts)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+		Expect(t, res.Entropies[0].File, "data.tar!inner/secret.txt")
+	})
+
+	t.Run("tar.gz", func(t *testing.T) {
+		var gzBuf bytes.Buffer
+		gzWriter := gzip.NewWriter(&gzBuf)
+		if _, err := gzWriter.Write(buildTar(t, "secret.txt", "token aX7qP2mZ9kLrT3vB8cQ1wN\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		if err := gzWriter.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		fsys := fstest.MapFS{"data.tar.gz": &fstest.MapFile{Data: gzBuf.Bytes()}}
+
+		opts := DefaultOptions()
+		opts.ScanArchives = true
+
+		res := Scan(fsys, opts)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+		Expect(t, res.Entropies[0].File, "data.tar.gz!secret.txt")
+	})
+
+	t.Run("malformed tar does not abort the scan", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"bad.tar":   &fstest.MapFile{Data: []byte("not a real tar")},
+			"clean.txt": &fstest.MapFile{Data: []byte("token aX7qP2mZ9kLrT3vB8cQ1wN\n")},
+		}
+
+		opts := DefaultOptions()
+		opts.ScanArchives = true
+
+		res := Scan(fsys, opts)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+	})
+}
+
+// buildTar returns the bytes of a tar archive containing a single file name with
+// the given content.
+func buildTar(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0o644}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,74 @@
+package entropy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Fingerprint computes the baseline identifier for a finding: sha256(file:line:token),
+// hex-encoded. It stays stable across runs as long as the finding itself doesn't move.
+func Fingerprint(file string, lineNum int, token string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%s", file, lineNum, token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadBaseline reads a baseline file of one fingerprint per line (blank lines and
+// lines starting with # are ignored) into a lookup set suitable for Options.Baseline.
+func LoadBaseline(path string) (map[string]bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fingerprints[line] = true
+	}
+
+	return fingerprints, nil
+}
+
+// WriteBaseline writes the fingerprints of the current top-N findings to path, one
+// per line, in the same format LoadBaseline reads. If path already has fingerprints,
+// they're merged in rather than replaced: running -baseline path -write-baseline path
+// to accept newly-reviewed findings would otherwise silently drop previously-accepted
+// fingerprints that no longer make the current top-N, and they'd reappear as "new"
+// findings on a later run even though nothing in the source changed.
+func WriteBaseline(path string, entropies *Entropies) error {
+	fingerprints, err := LoadBaseline(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if fingerprints == nil {
+		fingerprints = make(map[string]bool)
+	}
+
+	for _, entropy := range entropies.Entropies {
+		if entropy == (Entropy{}) {
+			break
+		}
+		fingerprints[Fingerprint(entropy.File, entropy.LineNum, entropy.Line)] = true
+	}
+
+	sorted := make([]string, 0, len(fingerprints))
+	for fingerprint := range fingerprints {
+		sorted = append(sorted, fingerprint)
+	}
+	slices.Sort(sorted)
+
+	var sb strings.Builder
+	for _, fingerprint := range sorted {
+		sb.WriteString(fingerprint)
+		sb.WriteString("\n")
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
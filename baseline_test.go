@@ -0,0 +1,74 @@
+package entropy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteBaseline(t *testing.T) {
+	t.Run("writes fingerprints for the current top-N", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.txt")
+		entropies := NewEntropies(2)
+		entropies.Add(Entropy{File: "a.go", LineNum: 1, Line: "aaa", Entropy: 1})
+
+		if err := WriteBaseline(path, entropies); err != nil {
+			t.Fatalf("WriteBaseline: %v", err)
+		}
+
+		got, err := LoadBaseline(path)
+		if err != nil {
+			t.Fatalf("LoadBaseline: %v", err)
+		}
+		Expect(t, got[Fingerprint("a.go", 1, "aaa")], true)
+	})
+
+	t.Run("merges with an existing baseline instead of replacing it", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "baseline.txt")
+
+		accepted := NewEntropies(1)
+		accepted.Add(Entropy{File: "a.go", LineNum: 1, Line: "aaa", Entropy: 1})
+		if err := WriteBaseline(path, accepted); err != nil {
+			t.Fatalf("WriteBaseline: %v", err)
+		}
+
+		// A later run with -top 1 finds only a new, higher-entropy result: the
+		// previously accepted fingerprint must survive the second write even
+		// though it no longer makes the top-N.
+		newFindings := NewEntropies(1)
+		newFindings.Add(Entropy{File: "b.go", LineNum: 2, Line: "bbb", Entropy: 2})
+		if err := WriteBaseline(path, newFindings); err != nil {
+			t.Fatalf("WriteBaseline: %v", err)
+		}
+
+		got, err := LoadBaseline(path)
+		if err != nil {
+			t.Fatalf("LoadBaseline: %v", err)
+		}
+		Expect(t, got[Fingerprint("a.go", 1, "aaa")], true)
+		Expect(t, got[Fingerprint("b.go", 2, "bbb")], true)
+	})
+
+	t.Run("writing to a path that doesn't exist yet", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "new", "baseline.txt")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+
+		entropies := NewEntropies(1)
+		entropies.Add(Entropy{File: "a.go", LineNum: 1, Line: "aaa", Entropy: 1})
+
+		if err := WriteBaseline(path, entropies); err != nil {
+			t.Fatalf("WriteBaseline: %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		if !strings.Contains(string(content), Fingerprint("a.go", 1, "aaa")) {
+			t.Errorf("expected baseline to contain the fingerprint, got %q", content)
+		}
+	})
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EwenQuim/entropy"
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/term"
+)
+
+// Supported values for the -format flag.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+// watchDebounce is how long to wait after a write event before re-scanning a file,
+// so that a burst of writes to the same file only triggers one re-scan.
+const watchDebounce = 100 * time.Millisecond
+
+// discrete and outputFormat are read by the printing and watch helpers below; they
+// mirror the -discrete and -format flags applied in main.
+var (
+	discrete     bool
+	outputFormat string
+)
+
+func main() {
+	defaultOpts := entropy.DefaultOptions()
+
+	minCharactersFlag := flag.Int("min", defaultOpts.MinCharacters, "Minimum number of characters in the line to consider computing entropy")
+	resultCountFlag := flag.Int("top", defaultOpts.ResultCount, "Number of results to display")
+	exploreHiddenFlag := flag.Bool("include-hidden", false, "Search in hidden files and folders (.git, .env...). Slows down the search.")
+	extensionsFlag := flag.String("ext", "", "Search only in files with these extensions. Comma separated list, e.g. -ext go,py,js (default all files)")
+	extensionsToIgnoreFlag := flag.String("ignore-ext", "", "Ignore files with these suffixes. Comma separated list, e.g. -ignore-ext min.css,_test.go,pdf,Test.php. Adds ignored extensions to the default ones.")
+	noDefaultExtensionsToIgnore := flag.Bool("ignore-ext-no-defaults", false, "Remove the default ignored extensions (default "+strings.Join(defaultOpts.ExtensionsToIgnore, ",")+")")
+	discreteFlag := flag.Bool("discrete", false, "Only show the entropy and file, not the line containing the possible secret")
+	binaryFilesFlag := flag.Bool("binary", false, "Include binary files in search. Slows down the search and may not be useful. A file is considered binary if the start of it is not valid utf8.")
+	archivesFlag := flag.Bool("archives", false, "Look inside archive files (.zip, .tar, .tar.gz, .tar.bz2) and scan their contents. Slows down the search.")
+	watchFlag := flag.Bool("watch", false, "After the initial scan, keep running and re-scan files as they change on disk. Useful as a pre-commit or dev-loop guard.")
+	formatFlag := flag.String("format", formatText, "Output format: text, json or sarif. json streams one result per line, sarif emits a single SARIF 2.1.0 document for CI integration.")
+	baselineFlag := flag.String("baseline", "", "Path to a baseline file of accepted finding fingerprints (one sha256(file:line:token) per line). Matching results are excluded from the output.")
+	writeBaselineFlag := flag.String("write-baseline", "", "Write the current findings' fingerprints to path, in the same format read by -baseline.")
+	maxLineFlag := flag.Int("max-line", defaultOpts.MaxLine, "Read buffer size in bytes. Lines of any length are still scanned in full; this only bounds how much is buffered at once.")
+	maxTokenFlag := flag.Int("max-token", defaultOpts.MaxToken, "Maximum bytes of a single token considered for entropy. Guards against pathologically large tokens (e.g. a huge base64 blob) dominating CPU.")
+
+	flag.CommandLine.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "%s [flags] file1 file2 file3 ...\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "Example: %s -top 10 -ext go,py,js .\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "Finds the highest entropy strings in files. The higher the entropy, the more random the string is. Useful for finding secrets (and alphabets, it seems).")
+		fmt.Fprintln(flag.CommandLine.Output(), "Please support me on GitHub: https://github.com/EwenQuim")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Apply flags
+	opts := defaultOpts
+	opts.MinCharacters = *minCharactersFlag
+	opts.ResultCount = *resultCountFlag
+	opts.ExploreHidden = *exploreHiddenFlag
+	opts.IncludeBinaryFiles = *binaryFilesFlag
+	opts.ScanArchives = *archivesFlag
+	opts.MaxLine = *maxLineFlag
+	opts.MaxToken = *maxTokenFlag
+	discrete = *discreteFlag
+	outputFormat = *formatFlag
+	switch outputFormat {
+	case formatText, formatJSON, formatSARIF:
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown -format %q, must be one of text, json, sarif\n", outputFormat)
+		os.Exit(1)
+	}
+
+	extensions := removeEmptyStrings(strings.Split(*extensionsFlag, ","))
+	extensionsToIgnoreString := *extensionsToIgnoreFlag + "," + strings.Join(defaultOpts.ExtensionsToIgnore, ",")
+	if *noDefaultExtensionsToIgnore {
+		extensionsToIgnoreString = *extensionsToIgnoreFlag
+	}
+	opts.Extensions = extensions
+	opts.ExtensionsToIgnore = removeEmptyStrings(strings.Split(extensionsToIgnoreString, ","))
+
+	if *baselineFlag != "" {
+		var err error
+		opts.Baseline, err = entropy.LoadBaseline(*baselineFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading baseline %s: %v\n", *baselineFlag, err)
+			os.Exit(1)
+		}
+	}
+
+	// Read file names from cli
+	fileNames := flag.Args()
+	if len(fileNames) == 0 {
+		fmt.Println("No files provided, defaults to current folder.")
+		fileNames = []string{"."}
+	}
+
+	entropies := entropy.NewEntropies(opts.ResultCount)
+	for _, fileName := range fileNames {
+		fsys, root, err := dirFSFor(fileName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning %s: %v\n", fileName, err)
+			continue
+		}
+		entropies.Merge(entropy.ScanRoot(fsys, opts, root))
+	}
+
+	redMark := "\033[31m"
+	resetMark := "\033[0m"
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		// If not a terminal, remove color
+		redMark = ""
+		resetMark = ""
+	}
+
+	printResults(entropies, redMark, resetMark)
+
+	if *writeBaselineFlag != "" {
+		if err := entropy.WriteBaseline(*writeBaselineFlag, entropies); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing baseline %s: %v\n", *writeBaselineFlag, err)
+			os.Exit(1)
+		}
+	}
+
+	if *watchFlag {
+		if err := watchFiles(entropies, fileNames, opts, redMark, resetMark); err != nil {
+			fmt.Fprintf(os.Stderr, "Error watching files: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// dirFSFor returns an fs.FS and the entry name to scan within it for fileName.
+// os.DirFS requires "." to resolve to a directory, so a single file can't be
+// scanned by rooting fsys at the file itself; instead fsys is rooted one level up
+// and the file's base name is returned as root.
+func dirFSFor(fileName string) (fs.FS, string, error) {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.IsDir() {
+		return os.DirFS(fileName), ".", nil
+	}
+	return os.DirFS(filepath.Dir(fileName)), filepath.Base(fileName), nil
+}
+
+func printResults(entropies *entropy.Entropies, redMark, resetMark string) {
+	switch outputFormat {
+	case formatJSON:
+		printResultsJSON(entropies)
+	case formatSARIF:
+		printResultsSARIF(entropies)
+	default:
+		printResultsText(entropies, redMark, resetMark)
+	}
+}
+
+func printResultsText(entropies *entropy.Entropies, redMark, resetMark string) {
+	for _, e := range entropies.Snapshot() {
+		if e == (entropy.Entropy{}) {
+			return
+		}
+		if discrete {
+			e.Line = ""
+		}
+		fmt.Printf("%.3f: %s%s:%d%s %s\n", e.Entropy, redMark, e.File, e.LineNum, resetMark, e.Line)
+	}
+}
+
+// jsonEntropy is one line of -format json output.
+type jsonEntropy struct {
+	Path    string  `json:"path"`
+	Line    int     `json:"line"`
+	Column  int     `json:"column,omitempty"`
+	Entropy float64 `json:"entropy"`
+	Token   string  `json:"token,omitempty"`
+}
+
+func printResultsJSON(entropies *entropy.Entropies) {
+	encoder := json.NewEncoder(os.Stdout)
+	for _, e := range entropies.Snapshot() {
+		if e == (entropy.Entropy{}) {
+			return
+		}
+
+		record := jsonEntropy{
+			Path:    e.File,
+			Line:    e.LineNum,
+			Column:  e.Column,
+			Entropy: e.Entropy,
+		}
+		if !discrete {
+			record.Token = e.Line
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding result as JSON: %v\n", err)
+		}
+	}
+}
+
+// SARIF 2.1.0 types, kept minimal to what entropy emits. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func printResultsSARIF(entropies *entropy.Entropies) {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "entropy"}}}
+
+	for _, e := range entropies.Snapshot() {
+		if e == (entropy.Entropy{}) {
+			break
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  "high-entropy-string",
+			Level:   "warning",
+			Message: sarifMessage{Text: fmt.Sprintf("High entropy string detected (entropy %.3f)", e.Entropy)},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: e.File},
+				Region:           sarifRegion{StartLine: e.LineNum, StartColumn: e.Column},
+			}}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding result as SARIF: %v\n", err)
+	}
+}
+
+// watchFiles keeps running after the initial scan, watching fileNames (and, for
+// directories, everything beneath them) for changes. Each write is debounced and
+// the changed file alone is re-scanned, merging into the existing top-N entropies.
+func watchFiles(entropies *entropy.Entropies, fileNames []string, opts entropy.Options, redMark, resetMark string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	for _, fileName := range fileNames {
+		if err := addWatches(watcher, fileName, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error watching %s: %v\n", fileName, err)
+		}
+	}
+
+	var mu sync.Mutex
+	timers := map[string]*time.Timer{}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			changedPath := event.Name
+
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(changedPath); err == nil && info.IsDir() {
+					if err := addWatches(watcher, changedPath, opts); err != nil {
+						fmt.Fprintf(os.Stderr, "Error watching %s: %v\n", changedPath, err)
+					}
+				}
+			}
+
+			mu.Lock()
+			if timer, exists := timers[changedPath]; exists {
+				timer.Stop()
+			}
+			timers[changedPath] = time.AfterFunc(watchDebounce, func() {
+				fsys, root, err := dirFSFor(changedPath)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error scanning %s: %v\n", changedPath, err)
+					return
+				}
+				entropies.Merge(entropy.ScanRoot(fsys, opts, root))
+				printResults(entropies, redMark, resetMark)
+			})
+			mu.Unlock()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "Watch error: %v\n", err)
+		}
+	}
+}
+
+// addWatches registers path (and, recursively, every non-hidden subdirectory if
+// path is a directory) with watcher so fsnotify reports writes beneath it.
+func addWatches(watcher *fsnotify.Watcher, path string, opts entropy.Options) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return watcher.Add(filepath.Dir(path))
+	}
+
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != path && entropy.IsFileHidden(d.Name()) && !opts.ExploreHidden {
+			return filepath.SkipDir
+		}
+		return watcher.Add(p)
+	})
+}
+
+func removeEmptyStrings(slice []string) []string {
+	slices.Sort(slice)
+	slice = slices.Compact(slice)
+
+	if len(slice) > 0 && slice[0] == "" {
+		return slice[1:]
+	}
+
+	return slice
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EwenQuim/entropy"
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestRemoveEmptyStrings(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{})), 0)
+	})
+
+	t.Run("single empty string", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{""})), 0)
+	})
+
+	t.Run("no empty strings", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{"a", "b", "c"})), 3)
+	})
+
+	t.Run("one empty string", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{"a", "", "c"})), 2)
+	})
+
+	t.Run("multiple consecutive empty strings", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{"a", "", "", "", "c"})), 2)
+	})
+
+	t.Run("multiple non-consecutive empty strings", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{"", "a", "", "", "", "c", ""})), 2)
+	})
+
+	t.Run("all empty strings", func(t *testing.T) {
+		Expect(t, len(removeEmptyStrings([]string{"", "", "", ""})), 0)
+	})
+}
+
+func TestAddWatches(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, ".hidden"), 0o755); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("NewWatcher: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := addWatches(watcher, dir, entropy.DefaultOptions()); err != nil {
+		t.Fatalf("addWatches: %v", err)
+	}
+
+	watched := watcher.WatchList()
+	Expect(t, slices.Contains(watched, dir), true)
+	Expect(t, slices.Contains(watched, filepath.Join(dir, "sub")), true)
+	Expect(t, slices.Contains(watched, filepath.Join(dir, ".hidden")), false)
+}
+
+// TestWatchFiles exercises watchFiles end to end: it starts watching a real
+// directory, writes a new file into it, and waits for the debounced re-scan to
+// pick up the secret the new file contains.
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	opts := entropy.DefaultOptions()
+	entropies := entropy.NewEntropies(opts.ResultCount)
+
+	go func() {
+		_ = watchFiles(entropies, []string{dir}, opts, "", "")
+	}()
+
+	// Give the watcher time to register dir before writing into it.
+	time.Sleep(50 * time.Millisecond)
+
+	filePath := filepath.Join(dir, "secret.txt")
+	if err := os.WriteFile(filePath, []byte("token aX7qP2mZ9kLrT3vB8cQ1wN\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if snapshot := entropies.Snapshot(); len(snapshot) > 0 && snapshot[0].Line == "aX7qP2mZ9kLrT3vB8cQ1wN" {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	t.Fatalf("watchFiles did not pick up the new file within the deadline, got %+v", entropies.Snapshot())
+}
+
+func TestDirFSFor(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "secrets.txt")
+	if err := os.WriteFile(filePath, []byte("token aX7qP2mZ9kLrT3vB8cQ1wN\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		fsys, root, err := dirFSFor(dir)
+		if err != nil {
+			t.Fatalf("dirFSFor: %v", err)
+		}
+		Expect(t, root, ".")
+
+		res := entropy.ScanRoot(fsys, entropy.DefaultOptions(), root)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		fsys, root, err := dirFSFor(filePath)
+		if err != nil {
+			t.Fatalf("dirFSFor: %v", err)
+		}
+		Expect(t, root, "secrets.txt")
+
+		res := entropy.ScanRoot(fsys, entropy.DefaultOptions(), root)
+		Expect(t, res.Entropies[0].Line, "aX7qP2mZ9kLrT3vB8cQ1wN")
+	})
+}
+
+func TestPrintResultsJSON(t *testing.T) {
+	entropies := entropy.NewEntropies(2)
+	entropies.Add(entropy.Entropy{Entropy: 4.5, File: "a.go", LineNum: 3, Column: 7, Line: "sup3rs3cr3t"})
+	entropies.Add(entropy.Entropy{Entropy: 2.1, File: "b.go", LineNum: 1, Column: 1, Line: "hello"})
+
+	t.Run("includes token by default", func(t *testing.T) {
+		discrete = false
+		lines := strings.Split(strings.TrimSpace(captureStdout(t, func() { printResultsJSON(entropies) })), "\n")
+		Expect(t, len(lines), 2)
+
+		var first jsonEntropy
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		Expect(t, first.Path, "a.go")
+		Expect(t, first.Line, 3)
+		Expect(t, first.Column, 7)
+		Expect(t, first.Token, "sup3rs3cr3t")
+	})
+
+	t.Run("omits token when discrete", func(t *testing.T) {
+		discrete = true
+		defer func() { discrete = false }()
+
+		out := captureStdout(t, func() { printResultsJSON(entropies) })
+		if strings.Contains(out, "sup3rs3cr3t") || strings.Contains(out, `"token"`) {
+			t.Errorf("expected token to be omitted in discrete mode, got %q", out)
+		}
+	})
+}
+
+func TestPrintResultsSARIF(t *testing.T) {
+	entropies := entropy.NewEntropies(2)
+	entropies.Add(entropy.Entropy{Entropy: 4.5, File: "a.go", LineNum: 3, Column: 7, Line: "sup3rs3cr3t"})
+
+	var log sarifLog
+	out := captureStdout(t, func() { printResultsSARIF(entropies) })
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	Expect(t, len(log.Runs), 1)
+	Expect(t, len(log.Runs[0].Results), 1)
+
+	result := log.Runs[0].Results[0]
+	Expect(t, result.RuleID, "high-entropy-string")
+	Expect(t, result.Level, "warning")
+	Expect(t, result.Locations[0].PhysicalLocation.ArtifactLocation.URI, "a.go")
+	Expect(t, result.Locations[0].PhysicalLocation.Region.StartLine, 3)
+	Expect(t, result.Locations[0].PhysicalLocation.Region.StartColumn, 7)
+}
+
+// captureStdout redirects os.Stdout for the duration of f and returns whatever was
+// written to it.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+
+	original := os.Stdout
+	os.Stdout = w
+	f()
+	os.Stdout = original
+	w.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read captured output: %v", err)
+	}
+
+	return string(data)
+}
+
+func Expect[T comparable](t *testing.T, got, expected T) {
+	t.Helper()
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,107 @@
+// Package entropy finds the highest-entropy (most likely secret) tokens inside a
+// set of files. It can be embedded by other Go tools via Scan, or driven from the
+// command line through cmd/entropy.
+package entropy
+
+import (
+	"math"
+	"slices"
+	"sync"
+)
+
+type Entropy struct {
+	Entropy float64 // Entropy of the line
+	File    string  // File where the line is found
+	LineNum int     // Line number in the file
+	Column  int     // Column (1-based, byte offset) of the token within the line
+	Line    string  // Line with high entropy
+}
+
+func NewEntropies(n int) *Entropies {
+	return &Entropies{
+		Entropies: make([]Entropy, n),
+		maxLength: n,
+	}
+}
+
+// Entropies should be created with NewEntropies(n).
+// It should not be written to manually, instead use Entropies.Add. The Entropies
+// field itself is not safe to read concurrently with Add/Merge from another
+// goroutine; use Snapshot for that.
+type Entropies struct {
+	mu        sync.Mutex
+	Entropies []Entropy // Ordered list of entropies with highest entropy first, with length fixed at creation
+	maxLength int
+}
+
+// Add assumes that es contains an ordered list of entropies of length es.maxLength.
+// It preserves ordering, and inserts an additional value e, if it has high enough entropy.
+// In that case, the entry with lowest entropy is rejected.
+func (es *Entropies) Add(e Entropy) {
+	// This condition is to avoid acquiring the lock (slow) if the entropy is not high enough.
+	// Not goroutine safe, but another check is made after acquiring the lock.
+	if es.Entropies[es.maxLength-1].Entropy >= e.Entropy {
+		return
+	}
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if es.Entropies[len(es.Entropies)-1].Entropy >= e.Entropy {
+		return
+	}
+
+	i, _ := slices.BinarySearchFunc(es.Entropies, e, func(a, b Entropy) int {
+		if b.Entropy > a.Entropy {
+			return 1
+		}
+		if a.Entropy > b.Entropy {
+			return -1
+		}
+		return 0
+	})
+
+	copy(es.Entropies[i+1:], es.Entropies[i:])
+	es.Entropies[i] = e
+}
+
+// Snapshot returns a copy of the current top-N entropies, safe to read or range
+// over while other goroutines continue to call Add/Merge on es. Callers that only
+// read es.Entropies (printing, encoding...) should go through Snapshot instead of
+// reading the field directly once es is shared with a concurrent writer.
+func (es *Entropies) Snapshot() []Entropy {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	return slices.Clone(es.Entropies)
+}
+
+// Merge adds every entry of other into es, preserving the top-N ordering. Useful
+// when scanning several roots (or fs.FS instances) into a single result set.
+func (es *Entropies) Merge(other *Entropies) {
+	for _, e := range other.Entropies {
+		if e == (Entropy{}) {
+			continue
+		}
+		es.Add(e)
+	}
+}
+
+func entropy(text string) float64 {
+	uniqueCharacters := make(map[rune]int64, len(text))
+	for _, r := range text {
+		uniqueCharacters[r]++
+	}
+
+	entropy := 0.0
+	for character := range uniqueCharacters {
+		res := float64(uniqueCharacters[character]) / float64(len(text))
+		if res == 0 {
+			continue
+		}
+
+		entropy -= res * math.Log2(res)
+	}
+
+	return entropy
+}
@@ -0,0 +1,279 @@
+package entropy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// extensionsToIgnoreDefault mirrors the CLI's default -ignore-ext list.
+const extensionsToIgnoreDefault = ".pdf,.png,.jpg,.jpeg,.zip,.mp4,.gif,.ttf,.doc,.docx,.xls,.xlsx,.ppt,.pptx,.mp3,.wav,.avi,.mov,.ogg,.wasm,.pyc"
+
+// maxLineDefault is the bufio.Reader buffer size used when Options.MaxLine is unset.
+// Unlike bufio.Scanner, this is not a hard cap on line length: lines of any size
+// stream through scanContent without ever being materialized in full.
+const maxLineDefault = 64 * 1024
+
+// maxTokenDefault caps how many bytes of a single token are kept for entropy
+// computation when Options.MaxToken is unset, so a multi-megabyte token (e.g. a
+// giant base64 blob) can't dominate CPU.
+const maxTokenDefault = 1 << 20 // 1 MiB
+
+// binarySniffLen is how many bytes of a file are inspected to decide whether it
+// looks binary, when Options.IncludeBinaryFiles is false.
+const binarySniffLen = 512
+
+// Options controls how Scan walks and filters the filesystem it's given.
+type Options struct {
+	MinCharacters      int             // Minimum number of characters in a token to consider computing entropy
+	ResultCount        int             // Number of results to keep
+	ExploreHidden      bool            // Explore hidden files and folders (.git, .env, node_modules...)
+	Extensions         []string        // Only scan files with these extensions. Empty means all files
+	ExtensionsToIgnore []string        // Skip files with these suffixes
+	IncludeBinaryFiles bool            // Include files that don't look like valid utf8 text
+	ScanArchives       bool            // Look inside .zip, .tar, .tar.gz and .tar.bz2 archives
+	Baseline           map[string]bool // Accepted finding fingerprints (see Fingerprint) to exclude from results
+	MaxLine            int             // bufio.Reader buffer size in bytes. 0 means maxLineDefault
+	MaxToken           int             // Max bytes of a single token considered for entropy. 0 means maxTokenDefault
+}
+
+// DefaultOptions returns the Options the CLI uses when no flags override them.
+func DefaultOptions() Options {
+	return Options{
+		MinCharacters:      8,
+		ResultCount:        10,
+		ExtensionsToIgnore: strings.Split(extensionsToIgnoreDefault, ","),
+		MaxLine:            maxLineDefault,
+		MaxToken:           maxTokenDefault,
+	}
+}
+
+// Scan walks fsys from its root and returns the Options.ResultCount highest-entropy
+// tokens found, ordered from highest to lowest. Use os.DirFS(dir) to scan a real
+// directory, fstest.MapFS for in-memory fixtures, or an embed.FS for embedded
+// assets. Errors encountered while walking are reported on stderr; they don't
+// abort the rest of the scan.
+func Scan(fsys fs.FS, opts Options) *Entropies {
+	return ScanRoot(fsys, opts, ".")
+}
+
+// ScanRoot is like Scan but starts from root instead of fsys's top level. Use it
+// to scan a single file by path: os.DirFS(file) doesn't work for a non-directory
+// file, since "." must resolve to a directory, so instead root fsys at the
+// file's parent directory (os.DirFS(filepath.Dir(file))) and pass
+// filepath.Base(file) as root.
+func ScanRoot(fsys fs.FS, opts Options, root string) *Entropies {
+	entropies := NewEntropies(opts.ResultCount)
+	if err := scanPath(fsys, entropies, opts, root); err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
+	}
+	return entropies
+}
+
+func scanPath(fsys fs.FS, entropies *Entropies, opts Options, name string) error {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	if IsFileHidden(info.Name()) && !opts.ExploreHidden {
+		return nil
+	}
+
+	isArchiveCandidate := opts.ScanArchives && isArchive(info.Name())
+
+	if !isFileIncluded(opts, info.Name()) && !isArchiveCandidate {
+		return nil
+	}
+
+	if info.IsDir() {
+		entries, err := fs.ReadDir(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		var wg sync.WaitGroup
+		for _, entry := range entries {
+			wg.Add(1)
+			go func(entry fs.DirEntry) {
+				defer wg.Done()
+				childName := path.Join(name, entry.Name())
+				if err := scanPath(fsys, entropies, opts, childName); err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading file %s: %v\n", childName, err)
+				}
+			}(entry)
+		}
+		wg.Wait()
+
+		return nil
+	}
+
+	if isArchiveCandidate {
+		return readArchive(entropies, opts, fsys, name)
+	}
+
+	file, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return scanContent(entropies, opts, name, file)
+}
+
+// scanContent streams r rune by rune, splitting it into whitespace-separated tokens
+// and recording the entropy of every token long enough to be considered, tagging
+// each result with displayName (the file path, possibly a synthetic archive!path
+// name). Unlike bufio.Scanner, it never requires a full line to fit in memory:
+// Options.MaxLine bounds the read buffer and Options.MaxToken bounds how much of
+// an individual token is kept for entropy computation, so a single pathologically
+// long line can't blow up memory or CPU.
+func scanContent(entropies *Entropies, opts Options, displayName string, r io.Reader) error {
+	bufSize := opts.MaxLine
+	if bufSize <= 0 {
+		bufSize = maxLineDefault
+	}
+	maxToken := opts.MaxToken
+	if maxToken <= 0 {
+		maxToken = maxTokenDefault
+	}
+
+	reader := bufio.NewReaderSize(r, bufSize)
+
+	if !opts.IncludeBinaryFiles {
+		binary, err := looksBinary(reader)
+		if err != nil {
+			return err
+		}
+		if binary {
+			return nil
+		}
+	}
+
+	lineNum := 1
+	column := 0     // byte offset of the next rune within the current line, 0-based
+	tokenStart := 0 // byte offset (1-based) where the in-progress token began
+	var token []byte
+	overflowed := false
+
+	flush := func() {
+		if len(token) == 0 {
+			return
+		}
+		if len(token) >= opts.MinCharacters {
+			text := string(token)
+			if !opts.Baseline[Fingerprint(displayName, lineNum, text)] {
+				entropies.Add(Entropy{
+					Entropy: entropy(text),
+					File:    displayName,
+					LineNum: lineNum,
+					Column:  tokenStart,
+					Line:    text,
+				})
+			}
+		}
+		token = nil
+		overflowed = false
+	}
+
+	for {
+		ch, _, err := reader.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ch == '\n':
+			flush()
+			lineNum++
+			column = 0
+			continue
+		case unicode.IsSpace(ch):
+			flush()
+		default:
+			if len(token) == 0 {
+				tokenStart = column + 1
+			}
+			if !overflowed {
+				if len(token)+utf8.RuneLen(ch) > maxToken {
+					overflowed = true
+				} else {
+					token = utf8.AppendRune(token, ch)
+				}
+			}
+		}
+
+		column += utf8.RuneLen(ch)
+	}
+	flush()
+
+	return nil
+}
+
+// looksBinary reports whether the first binarySniffLen bytes of reader look like
+// binary data (i.e. aren't valid utf8), without consuming them: reader.Peek leaves
+// the bytes available for the scan that follows.
+func looksBinary(reader *bufio.Reader) (bool, error) {
+	prefix, err := reader.Peek(binarySniffLen)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return false, err
+	}
+	if len(prefix) == 0 {
+		return false, nil
+	}
+
+	// The peeked prefix may end mid-rune; trim back to the last rune boundary
+	// before validating so a valid file isn't misdetected as binary.
+	for i := len(prefix); i > 0 && i > len(prefix)-utf8.UTFMax; i-- {
+		if utf8.Valid(prefix[:i]) {
+			return false, nil
+		}
+		if utf8.RuneStart(prefix[i-1]) {
+			break
+		}
+	}
+
+	return true, nil
+}
+
+// IsFileHidden reports whether filename (a base name or relative path) is
+// considered hidden: it starts with a dot, or is named node_modules.
+func IsFileHidden(filename string) bool {
+	if filename == "." {
+		return false
+	}
+	filename = strings.TrimPrefix(filename, "./")
+
+	return strings.HasPrefix(filename, ".") || filename == "node_modules"
+}
+
+// isFileIncluded returns true if the file should be included in the search
+func isFileIncluded(opts Options, filename string) bool {
+	for _, ext := range opts.ExtensionsToIgnore {
+		if strings.HasSuffix(filename, ext) {
+			return false
+		}
+	}
+
+	if len(opts.Extensions) == 0 {
+		return true
+	}
+
+	for _, ext := range opts.Extensions {
+		if strings.HasSuffix(filename, ext) {
+			return true
+		}
+	}
+
+	return false
+}